@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// AcceleratorBuilder installs the driver, container runtime hook, and device
+// plugin daemonset preload for each accelerator configured on this instance
+// group, dispatching to the right installer for the accelerator's vendor.
+type AcceleratorBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &AcceleratorBuilder{}
+
+// Build adds one installer task per configured accelerator.
+func (b *AcceleratorBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	for _, accelerator := range b.NodeupConfig.Accelerators {
+		installer, err := acceleratorInstallerFor(accelerator)
+		if err != nil {
+			return err
+		}
+		c.AddTask(installer)
+	}
+
+	return nil
+}
+
+// acceleratorInstallerFor returns the fi.Task that installs the driver,
+// container runtime hooks, and device plugin daemonset preload for the given
+// accelerator, dispatching on its vendor.
+func acceleratorInstallerFor(accelerator nodeup.AcceleratorConfig) (fi.Task, error) {
+	switch accelerator.Vendor {
+	case nodeup.AcceleratorVendorNvidia:
+		return &nvidiaAcceleratorInstallTask{accelerator: accelerator}, nil
+	case nodeup.AcceleratorVendorAMD:
+		return &amdAcceleratorInstallTask{accelerator: accelerator}, nil
+	case nodeup.AcceleratorVendorIntelHabana:
+		return &intelHabanaAcceleratorInstallTask{accelerator: accelerator}, nil
+	case nodeup.AcceleratorVendorAWSNeuron:
+		return &awsNeuronAcceleratorInstallTask{accelerator: accelerator}, nil
+	default:
+		return nil, fmt.Errorf("unknown accelerator vendor %q", accelerator.Vendor)
+	}
+}
+
+type nvidiaAcceleratorInstallTask struct {
+	accelerator nodeup.AcceleratorConfig
+}
+
+func (t *nvidiaAcceleratorInstallTask) String() string {
+	return fmt.Sprintf("nvidiaAcceleratorInstallTask: driver=%s", t.accelerator.DriverVersion)
+}
+
+func (t *nvidiaAcceleratorInstallTask) Run(c *fi.Context) error {
+	return installAccelerator(t.accelerator, "nvidia-driver-installer", "nvidia-container-runtime")
+}
+
+type amdAcceleratorInstallTask struct {
+	accelerator nodeup.AcceleratorConfig
+}
+
+func (t *amdAcceleratorInstallTask) String() string {
+	return fmt.Sprintf("amdAcceleratorInstallTask: driver=%s", t.accelerator.DriverVersion)
+}
+
+func (t *amdAcceleratorInstallTask) Run(c *fi.Context) error {
+	return installAccelerator(t.accelerator, "rocm-installer", "")
+}
+
+type intelHabanaAcceleratorInstallTask struct {
+	accelerator nodeup.AcceleratorConfig
+}
+
+func (t *intelHabanaAcceleratorInstallTask) String() string {
+	return fmt.Sprintf("intelHabanaAcceleratorInstallTask: driver=%s", t.accelerator.DriverVersion)
+}
+
+func (t *intelHabanaAcceleratorInstallTask) Run(c *fi.Context) error {
+	return installAccelerator(t.accelerator, "habanalabs-installer", "habana-container-runtime")
+}
+
+type awsNeuronAcceleratorInstallTask struct {
+	accelerator nodeup.AcceleratorConfig
+}
+
+func (t *awsNeuronAcceleratorInstallTask) String() string {
+	return fmt.Sprintf("awsNeuronAcceleratorInstallTask: driver=%s", t.accelerator.DriverVersion)
+}
+
+func (t *awsNeuronAcceleratorInstallTask) Run(c *fi.Context) error {
+	return installAccelerator(t.accelerator, "aws-neuron-driver", "")
+}
+
+// installAccelerator runs the vendor driver installer package, installs the
+// vendor's container runtime hook package if it has one, and preloads the device
+// plugin daemonset image so it's ready by the time the kubelet registers the node.
+// It does not register accelerator.RuntimeClass or apply NodeLabels or NodeTaints.
+func installAccelerator(accelerator nodeup.AcceleratorConfig, driverPackage, runtimeHookPackage string) error {
+	if err := installPackage(driverPackage, accelerator.DriverVersion); err != nil {
+		return fmt.Errorf("installing %s driver %s: %w", accelerator.Vendor, accelerator.DriverVersion, err)
+	}
+
+	if runtimeHookPackage != "" {
+		if err := installPackage(runtimeHookPackage, ""); err != nil {
+			return fmt.Errorf("installing %s container runtime hook: %w", accelerator.Vendor, err)
+		}
+	}
+
+	if accelerator.DevicePluginImage != "" {
+		if err := preloadImage(accelerator.DevicePluginImage); err != nil {
+			return fmt.Errorf("preloading %s device plugin image %s: %w", accelerator.Vendor, accelerator.DevicePluginImage, err)
+		}
+	}
+
+	return nil
+}
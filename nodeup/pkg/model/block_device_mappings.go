@@ -0,0 +1,246 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// kopsManagedDevices are device names kops already formats and mounts itself
+// (the root volume, and the instance-store/ephemeral volumes used for etcd and
+// containerd on some instance types). BlockDeviceMappingBuilder must not touch these.
+var kopsManagedDevices = map[string]bool{
+	"/dev/xvda":  true,
+	"/dev/sda1":  true,
+	"/dev/xvdb":  true,
+	"/dev/nvme0": true,
+}
+
+// BlockDeviceMappingBuilder reconciles the extra volumes requested via
+// nodeup.Config.BlockDeviceMappings: it waits for each device to appear, formats
+// it if it has no filesystem yet, and mounts it at the requested path.
+type BlockDeviceMappingBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &BlockDeviceMappingBuilder{}
+
+// Build adds one mount-reconciliation task per configured block device mapping.
+func (b *BlockDeviceMappingBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	for _, mapping := range b.NodeupConfig.BlockDeviceMappings {
+		if kopsManagedDevices[mapping.DeviceName] {
+			continue
+		}
+		if mapping.MountPoint == "" {
+			continue
+		}
+
+		c.AddTask(&blockDeviceMountTask{mapping: mapping})
+	}
+
+	return nil
+}
+
+// blockDeviceMountTask idempotently formats and mounts a single block device mapping.
+type blockDeviceMountTask struct {
+	mapping nodeup.BlockDeviceMapping
+}
+
+func (t *blockDeviceMountTask) String() string {
+	return fmt.Sprintf("blockDeviceMountTask: %s -> %s", t.mapping.DeviceName, t.mapping.MountPoint)
+}
+
+// Run waits for the device, formats it if it is empty, and mounts it.
+func (t *blockDeviceMountTask) Run(c *fi.Context) error {
+	devicePath, err := t.resolveDevicePath()
+	if err != nil {
+		return err
+	}
+
+	formatted, err := isFormatted(devicePath)
+	if err != nil {
+		return fmt.Errorf("checking filesystem on %s: %w", devicePath, err)
+	}
+
+	filesystem := t.mapping.Filesystem
+	if filesystem == "" {
+		filesystem = "ext4"
+	}
+
+	if !formatted {
+		klog.Infof("formatting %s as %s for mount point %s", devicePath, filesystem, t.mapping.MountPoint)
+		mkfs := "mkfs." + filesystem
+		if out, err := exec.Command(mkfs, devicePath).CombinedOutput(); err != nil {
+			return fmt.Errorf("formatting %s with %s: %w: %s", devicePath, mkfs, err, string(out))
+		}
+	}
+
+	if err := os.MkdirAll(t.mapping.MountPoint, 0o755); err != nil {
+		return fmt.Errorf("creating mount point %s: %w", t.mapping.MountPoint, err)
+	}
+
+	mounted, err := isMounted(t.mapping.MountPoint)
+	if err != nil {
+		return fmt.Errorf("checking mounts for %s: %w", t.mapping.MountPoint, err)
+	}
+	if !mounted {
+		if out, err := exec.Command("mount", devicePath, t.mapping.MountPoint).CombinedOutput(); err != nil {
+			return fmt.Errorf("mounting %s at %s: %w: %s", devicePath, t.mapping.MountPoint, err, string(out))
+		}
+	}
+
+	uuid, err := filesystemUUID(devicePath)
+	if err != nil {
+		return fmt.Errorf("reading filesystem UUID of %s: %w", devicePath, err)
+	}
+
+	return ensureFstabEntry("UUID="+uuid, t.mapping.MountPoint, filesystem)
+}
+
+// resolveDevicePath waits for the configured device to appear, falling back to
+// the NVMe device that was attached under the requested name (used on Nitro
+// instances, where /dev/xvdc-style names are not guaranteed to map to the same
+// kernel device) if the plain path isn't found.
+func (t *blockDeviceMountTask) resolveDevicePath() (string, error) {
+	deadline := time.Now().Add(2 * time.Minute)
+	for {
+		if _, err := os.Stat(t.mapping.DeviceName); err == nil {
+			return t.mapping.DeviceName, nil
+		}
+
+		if nvmePath, err := resolveNVMeDeviceByRequestedName(t.mapping.DeviceName); err == nil && nvmePath != "" {
+			return nvmePath, nil
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for block device %s to appear", t.mapping.DeviceName)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// resolveNVMeDeviceByRequestedName maps a requested EC2 device name (e.g.
+// "/dev/xvdc") to the NVMe block device it was attached as, on Nitro-based
+// instance types where /dev/xvdc-style names never get a kernel device.
+//
+// The NVMe serial number for an EBS volume is the volume's ID, not the
+// device name it was requested under, so it can't be matched against
+// deviceName directly. Nitro instances instead expose the requested device
+// name through the `ebsnvme-id` helper (shipped in amazon-ec2-utils),
+// which reads it from the NVMe controller's vendor-specific identify data.
+func resolveNVMeDeviceByRequestedName(deviceName string) (string, error) {
+	matches, err := filepath.Glob("/dev/nvme*n1")
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range matches {
+		out, err := exec.Command("ebsnvme-id", "--block-dev", candidate).CombinedOutput()
+		if err != nil {
+			continue
+		}
+		if requested := strings.TrimSpace(string(out)); requested == deviceName {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// isFormatted reports whether devicePath already has a recognized filesystem.
+// blkid exits with status 2 specifically when it finds no filesystem
+// signature; any other error (missing binary, permission denied, a device
+// that can't be opened) is a real failure and must not be treated the same,
+// since doing so would cause the caller to run mkfs on a device that may
+// already hold data.
+func isFormatted(devicePath string) (bool, error) {
+	out, err := exec.Command("blkid", "-o", "value", "-s", "TYPE", devicePath).Output()
+	if err == nil {
+		return strings.TrimSpace(string(out)) != "", nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+		return false, nil
+	}
+	return false, fmt.Errorf("running blkid on %s: %w", devicePath, err)
+}
+
+// filesystemUUID returns the UUID blkid reports for devicePath's filesystem,
+// for use as a stable fstab identifier: NVMe device enumeration order on
+// Nitro instances isn't guaranteed stable across reboots, so the transient
+// /dev/nvmeXn1 path can't be trusted to still point at the same volume.
+func filesystemUUID(devicePath string) (string, error) {
+	out, err := exec.Command("blkid", "-o", "value", "-s", "UUID", devicePath).Output()
+	if err != nil {
+		return "", err
+	}
+	uuid := strings.TrimSpace(string(out))
+	if uuid == "" {
+		return "", fmt.Errorf("blkid reported no UUID for %s", devicePath)
+	}
+	return uuid, nil
+}
+
+func isMounted(mountPoint string) (bool, error) {
+	data, err := os.ReadFile("/proc/mounts")
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[1] == mountPoint {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ensureFstabEntry appends a /etc/fstab entry for the device if one isn't
+// already present. identifier should be a stable device identifier such as
+// "UUID=...", not a transient /dev path.
+func ensureFstabEntry(identifier, mountPoint, filesystem string) error {
+	data, err := os.ReadFile("/etc/fstab")
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	entry := fmt.Sprintf("%s %s %s defaults,nofail 0 2", identifier, mountPoint, filesystem)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[1] == mountPoint {
+			return nil
+		}
+	}
+
+	f, err := os.OpenFile("/etc/fstab", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(entry + "\n")
+	return err
+}
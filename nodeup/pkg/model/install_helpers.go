@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+)
+
+// installPackage installs the named package via the node's package manager,
+// pinning to version if one is given. kops supports node images across
+// several package management families (Debian/Ubuntu, Amazon Linux/RHEL),
+// so this dispatches to whichever one is actually present on the node
+// rather than assuming apt-get.
+func installPackage(name, version string) error {
+	switch {
+	case commandExists("apt-get"):
+		pkg := name
+		if version != "" {
+			pkg = fmt.Sprintf("%s=%s", name, version)
+		}
+		return runInstaller("apt-get", "install", "-y", pkg)
+
+	case commandExists("dnf"):
+		return runInstaller("dnf", "install", "-y", packageNameVersion(name, version, "-"))
+
+	case commandExists("yum"):
+		return runInstaller("yum", "install", "-y", packageNameVersion(name, version, "-"))
+
+	default:
+		// Flatcar and similar image families have no general-purpose package
+		// manager; accelerator drivers there need a vendor-specific install
+		// path (e.g. a sysext or toolbox image) rather than installPackage.
+		return fmt.Errorf("installing %s: no supported package manager (apt-get, dnf, yum) found on this node", name)
+	}
+}
+
+// packageNameVersion formats name and version the way rpm-based package
+// managers expect (name-version), omitting the separator when no version is given.
+func packageNameVersion(name, version, sep string) string {
+	if version == "" {
+		return name
+	}
+	return name + sep + version
+}
+
+func runInstaller(command string, args ...string) error {
+	out, err := exec.Command(command, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %w: %s", command, strings.Join(args, " "), err, string(out))
+	}
+	return nil
+}
+
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// preloadImage pulls the given image into containerd's content store ahead of
+// time, so it's cached before any pod requests it.
+func preloadImage(image string) error {
+	client, err := containerd.New("/run/containerd/containerd.sock")
+	if err != nil {
+		return fmt.Errorf("connecting to containerd: %w", err)
+	}
+	defer client.Close()
+
+	ctx := namespaces.WithNamespace(context.Background(), "k8s.io")
+	_, err = client.Pull(ctx, image, containerd.WithPullUnpack)
+	return err
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// WarmPoolImageBuilder pre-pulls the warm pool images configured for this
+// instance group's OS, verifying each one's digest against its configured
+// Hash. This only runs on warm pool instances (EnableLifecycleHook is set),
+// and a verification failure fails the lifecycle hook instead of silently
+// leaving the warm pool instance with a drifted image cached.
+type WarmPoolImageBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &WarmPoolImageBuilder{}
+
+// Build adds one pre-pull task per warm pool image configured for this node's OS.
+func (b *WarmPoolImageBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if !b.NodeupConfig.EnableLifecycleHook {
+		return nil
+	}
+
+	images := b.NodeupConfig.WarmPoolImages[b.BootConfig.OS]
+	for _, image := range images {
+		c.AddTask(&warmPoolImagePullTask{
+			image:        image,
+			registryAuth: b.NodeupConfig.WarmPoolRegistryAuth,
+			configServer: b.ConfigServer,
+		})
+	}
+
+	return nil
+}
+
+// warmPoolImagePullTask pre-pulls and verifies a single warm pool image.
+type warmPoolImagePullTask struct {
+	image        *nodeup.Image
+	registryAuth map[string]nodeup.RegistryCredential
+	configServer fi.ConfigServer
+}
+
+func (t *warmPoolImagePullTask) String() string {
+	return fmt.Sprintf("warmPoolImagePullTask: %s", t.image.Name)
+}
+
+// Run pre-pulls the image via containerd's content store and verifies its
+// digest against the configured Hash, returning an error (which fails the
+// warm pool lifecycle hook) on any mismatch.
+func (t *warmPoolImagePullTask) Run(c *fi.Context) error {
+	client, err := containerd.New("/run/containerd/containerd.sock")
+	if err != nil {
+		return fmt.Errorf("connecting to containerd: %w", err)
+	}
+	defer client.Close()
+
+	ctx := namespaces.WithNamespace(context.Background(), "k8s.io")
+
+	resolver, err := t.resolver(ctx)
+	if err != nil {
+		return fmt.Errorf("resolving registry auth for %s: %w", t.image.Name, err)
+	}
+
+	klog.Infof("pre-pulling warm pool image %s", t.image.Name)
+	image, err := client.Pull(ctx, t.image.Name, containerd.WithPullUnpack, containerd.WithResolver(resolver))
+	if err != nil {
+		return fmt.Errorf("pulling warm pool image %s: %w", t.image.Name, err)
+	}
+
+	if t.image.Hash != "" {
+		actual := image.Target().Digest
+		expected := t.image.Hash
+		if !strings.Contains(expected, ":") {
+			expected = string(actual.Algorithm()) + ":" + expected
+		}
+		if actual.String() != expected {
+			return fmt.Errorf("warm pool image %s: digest %s does not match expected hash %s", t.image.Name, actual.String(), expected)
+		}
+	}
+
+	return nil
+}
+
+// resolver resolves registry credentials for the image's host via the kops
+// secret store, through the existing ConfigServer, rather than embedding the
+// credential directly in nodeup.Config.
+func (t *warmPoolImagePullTask) resolver(ctx context.Context) (remotes.Resolver, error) {
+	host := registryHost(t.image.Name)
+	credential, ok := t.registryAuth[host]
+	if !ok {
+		return docker.NewResolver(docker.ResolverOptions{}), nil
+	}
+
+	secret, err := t.configServer.GetSecret(ctx, credential.SecretName)
+	if err != nil {
+		return nil, fmt.Errorf("fetching registry credential %q: %w", credential.SecretName, err)
+	}
+
+	authorizer := docker.NewDockerAuthorizer(docker.WithAuthCreds(func(host string) (string, string, error) {
+		return secret.Username, secret.Password, nil
+	}))
+	return docker.NewResolver(docker.ResolverOptions{Authorizer: authorizer}), nil
+}
+
+func registryHost(image string) string {
+	if idx := strings.Index(image, "/"); idx > 0 && strings.ContainsAny(image[:idx], ".:") {
+		return image[:idx]
+	}
+	return "docker.io"
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// CloudProviderID identifies a cloud provider.
+type CloudProviderID string
+
+const (
+	CloudProviderAWS CloudProviderID = "aws"
+	CloudProviderGCE CloudProviderID = "gce"
+)
+
+// UpdatePolicyAutomatic is the default update policy: updates are applied automatically.
+const UpdatePolicyAutomatic = "automatic"
+
+// Cluster represents a kops cluster.
+type Cluster struct {
+	metav1.ObjectMeta
+
+	Spec ClusterSpec
+}
+
+// ClusterSpec is the spec for a Cluster.
+type ClusterSpec struct {
+	ContainerRuntime               string
+	Channels                       []string
+	Hooks                          []HookSpec
+	FileAssets                     []FileAssetSpec
+	UpdatePolicy                   *string
+	KubeAPIServer                  *KubeAPIServerConfig
+	CloudProvider                  CloudProviderSpec
+	Networking                     NetworkingSpec
+	ExternalCloudControllerManager *ExternalCloudControllerManagerConfig
+	KubernetesVersion              string
+}
+
+// CloudProviderSpec configures the cloud providers a cluster may run on.
+type CloudProviderSpec struct {
+	AWS *AWSSpec
+	GCE *GCESpec
+}
+
+// AWSSpec is the AWS-specific cluster configuration.
+type AWSSpec struct {
+	DisableSecurityGroupIngress *bool
+	ElbSecurityGroup            *string
+	NodeIPFamilies              []string
+	WarmPool                    WarmPoolSpec
+}
+
+// GCESpec is the GCE-specific cluster configuration.
+type GCESpec struct {
+	Multizone          *bool
+	NodeTags           *string
+	NodeInstancePrefix *string
+}
+
+// NetworkingSpec configures the cluster's networking.
+type NetworkingSpec struct {
+	AmazonVPC *AmazonVPCNetworkingSpec
+}
+
+// AmazonVPCNetworkingSpec selects the AWS VPC CNI.
+type AmazonVPCNetworkingSpec struct{}
+
+// ExternalCloudControllerManagerConfig configures the external CCM.
+type ExternalCloudControllerManagerConfig struct{}
+
+// KubeAPIServerConfig is the configuration for the kube-apiserver.
+type KubeAPIServerConfig struct{}
+
+// ContainerdConfig holds the configuration for containerd.
+type ContainerdConfig struct {
+	Version *string
+}
+
+func (c *ClusterSpec) GetCloudProvider() CloudProviderID {
+	switch {
+	case c.CloudProvider.AWS != nil:
+		return CloudProviderAWS
+	case c.CloudProvider.GCE != nil:
+		return CloudProviderGCE
+	}
+	return ""
+}
+
+// IsKubernetesLT returns true if the cluster's Kubernetes version is older than the given version.
+// It returns false if either version cannot be parsed.
+func (c *Cluster) IsKubernetesLT(version string) bool {
+	clusterVersion, err := utilversion.ParseGeneric(c.Spec.KubernetesVersion)
+	if err != nil {
+		return false
+	}
+	other, err := utilversion.ParseGeneric(version)
+	if err != nil {
+		return false
+	}
+	return clusterVersion.LessThan(other)
+}
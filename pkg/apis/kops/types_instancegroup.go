@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// InstanceGroupRole indicates the role of an instance group's members.
+type InstanceGroupRole string
+
+const (
+	InstanceGroupRoleMaster InstanceGroupRole = "Master"
+	InstanceGroupRoleNode   InstanceGroupRole = "Node"
+)
+
+// InstanceGroup represents a group of instances sharing the same configuration.
+type InstanceGroup struct {
+	metav1.ObjectMeta
+
+	Spec InstanceGroupSpec
+}
+
+// InstanceGroupSpec is the spec for an InstanceGroup.
+type InstanceGroupSpec struct {
+	Role        InstanceGroupRole
+	MachineType string
+	Image       string
+
+	// OS is the operating system that runs on members of this instance group,
+	// e.g. "linux" or "windows". When empty, it is "windows" if Image contains
+	// "windows", and "linux" otherwise.
+	OS string
+
+	SysctlParameters []string
+	VolumeMounts     []VolumeMountSpec
+	FileAssets       []FileAssetSpec
+	Hooks            []HookSpec
+	UpdatePolicy     *string
+	Kubelet          *KubeletConfigSpec
+	WarmPool         *WarmPoolSpec
+
+	// WindowsKubelet is the kubelet configuration for Windows members of this
+	// instance group. It is independent of Kubelet, which only applies to
+	// Linux members; it has no effect unless the instance group's OS resolves to
+	// "windows" (see OS).
+	WindowsKubelet *KubeletConfigSpec
+
+	// NvidiaGPU contains the configuration for an Nvidia GPU attached to members
+	// of this instance group.
+	//
+	// Deprecated: use Accelerators instead.
+	NvidiaGPU *NvidiaGPUConfig
+
+	// Accelerators configures the extended-resource accelerators (GPUs and similar
+	// devices) attached to members of this instance group.
+	Accelerators []AcceleratorSpec
+
+	// BlockDeviceMappings are additional volumes to attach to members of this
+	// instance group, beyond the root volume kops already manages.
+	BlockDeviceMappings []BlockDeviceMappingSpec
+}
+
+// HasAPIServer returns true if members of this instance group run the Kubernetes API server.
+func (g *InstanceGroup) HasAPIServer() bool {
+	return g.Spec.Role == InstanceGroupRoleMaster
+}
+
+// KubeletConfigSpec is the kubelet configuration.
+type KubeletConfigSpec struct{}
+
+// NvidiaGPUConfig is the legacy, Nvidia-only GPU configuration.
+type NvidiaGPUConfig struct {
+	// DriverVersion is the Nvidia driver version to install.
+	DriverVersion string
+}
+
+// AcceleratorSpec configures an extended-resource accelerator device attached to
+// members of an instance group.
+type AcceleratorSpec struct {
+	// Vendor is the accelerator vendor, e.g. "nvidia", "amd", "intel-habana", "aws-neuron".
+	Vendor string
+	// DriverVersion is the vendor driver version to install.
+	DriverVersion string
+	// DevicePluginImage is the device plugin daemonset image to preload.
+	DevicePluginImage string
+	// RuntimeClass is the container runtime class to register for pods that request this accelerator.
+	RuntimeClass string
+	// NodeLabels are additional labels to apply to the node for this accelerator.
+	NodeLabels map[string]string
+	// NodeTaints are additional taints to apply to the node for this accelerator.
+	NodeTaints []string
+	// Options holds vendor-specific configuration that doesn't warrant its own field.
+	Options map[string]string
+}
+
+// BlockDeviceMappingSpec describes an additional volume to attach to members of
+// an instance group, beyond the root and any ephemeral volumes kops already manages.
+type BlockDeviceMappingSpec struct {
+	// DeviceName is the device name as exposed to the instance, e.g. "/dev/xvdc".
+	DeviceName string
+	// VolumeSize is the size of the volume, in GB.
+	VolumeSize int64
+	// VolumeType is the cloud-specific volume type, e.g. "gp3".
+	VolumeType string
+	// IOPS is the provisioned IOPS for volume types that support it.
+	IOPS *int64
+	// Throughput is the provisioned throughput (MB/s) for volume types that support it.
+	Throughput *int64
+	// Encrypted indicates whether the volume should be encrypted.
+	Encrypted *bool
+	// KMSKeyID is the ID of the KMS key used to encrypt the volume, if any.
+	KMSKeyID string
+	// Filesystem is the filesystem to format the volume with, e.g. "xfs" or "ext4".
+	Filesystem string
+	// MountPoint is the path at which the volume should be mounted.
+	MountPoint string
+}
+
+// VolumeMountSpec describes a volume mount.
+type VolumeMountSpec struct {
+	Device string
+	Path   string
+}
+
+// FileAssetSpec describes a file to be installed on the node.
+type FileAssetSpec struct {
+	Name     string
+	Path     string
+	Content  string
+	Roles    []InstanceGroupRole
+	IsBase64 bool
+}
+
+// HookSpec describes a custom action to run during bootstrap.
+type HookSpec struct {
+	Name     string
+	Roles    []InstanceGroupRole
+	Disabled bool
+	Manifest string
+}
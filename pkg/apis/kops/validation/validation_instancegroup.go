@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+var validInstanceGroupOSes = map[string]bool{
+	"":        true, // resolved from Image, else linux
+	"linux":   true,
+	"windows": true,
+}
+
+var validAcceleratorVendors = map[string]bool{
+	"nvidia":       true,
+	"amd":          true,
+	"intel-habana": true,
+	"aws-neuron":   true,
+}
+
+// ValidateInstanceGroupSpec validates the fields of an InstanceGroupSpec that
+// do not require a full cluster to validate.
+func ValidateInstanceGroupSpec(spec *kops.InstanceGroupSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !validInstanceGroupOSes[spec.OS] {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("os"), spec.OS, []string{"linux", "windows"}))
+	}
+
+	for i, accelerator := range spec.Accelerators {
+		path := fieldPath.Child("accelerators").Index(i)
+		if accelerator.Vendor == "" {
+			allErrs = append(allErrs, field.Required(path.Child("vendor"), "vendor is required"))
+		} else if !validAcceleratorVendors[accelerator.Vendor] {
+			allErrs = append(allErrs, field.NotSupported(path.Child("vendor"), accelerator.Vendor, []string{"nvidia", "amd", "intel-habana", "aws-neuron"}))
+		}
+	}
+
+	for i, mapping := range spec.BlockDeviceMappings {
+		path := fieldPath.Child("blockDeviceMappings").Index(i)
+		if mapping.DeviceName == "" {
+			allErrs = append(allErrs, field.Required(path.Child("deviceName"), "deviceName is required"))
+		}
+		if mapping.VolumeSize <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("volumeSize"), mapping.VolumeSize, "volumeSize must be greater than zero"))
+		}
+		if mapping.MountPoint != "" && mapping.Filesystem == "" {
+			allErrs = append(allErrs, field.Required(path.Child("filesystem"), "filesystem is required when mountPoint is set"))
+		}
+	}
+
+	return allErrs
+}
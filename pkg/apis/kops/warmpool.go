@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+// WarmPoolSpec configures an autoscaling group warm pool.
+type WarmPoolSpec struct {
+	// Enabled turns the warm pool on or off.
+	Enabled *bool
+	// EnableLifecycleHook completes a lifecycle hook before an instance leaves the warm pool.
+	EnableLifecycleHook bool
+	// MinSize is the minimum number of warm instances to keep available.
+	MinSize *int64
+	// MaxSize is the maximum number of warm instances to keep available.
+	MaxSize *int64
+	// Images are additional container images to pre-pull on warm pool instances,
+	// keyed by OS ("linux" or "windows").
+	Images map[string][]WarmPoolImageSpec
+	// RegistryAuth references kops secret store entries to use when pre-pulling
+	// Images from a private registry, keyed by registry host.
+	RegistryAuth map[string]RegistryCredentialSpec
+}
+
+// WarmPoolImageSpec is a container image to pre-pull on warm pool instances.
+type WarmPoolImageSpec struct {
+	// Name is the image name, as passed to "docker run".
+	Name string
+	// Sources is a list of URLs from which we should download the image.
+	Sources []string
+	// Hash is the hash of the image, to verify its integrity before trusting it.
+	Hash string
+}
+
+// RegistryCredentialSpec is a reference to a kops secret store entry holding
+// registry credentials, rather than the credential itself.
+type RegistryCredentialSpec struct {
+	// SecretName is the name of the secret in the kops secret store.
+	SecretName string
+}
+
+// IsEnabled returns whether the warm pool is enabled.
+func (w WarmPoolSpec) IsEnabled() bool {
+	return w.Enabled != nil && *w.Enabled
+}
+
+// ResolveDefaults returns the effective warm pool spec for an instance group,
+// allowing the instance group to opt out of a cluster-wide warm pool.
+func (w WarmPoolSpec) ResolveDefaults(ig *InstanceGroup) WarmPoolSpec {
+	if ig.Spec.WarmPool != nil {
+		return *ig.Spec.WarmPool
+	}
+	return w
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import "k8s.io/kops/pkg/apis/kops"
+
+// AcceleratorVendor identifies the vendor of an accelerator device.
+type AcceleratorVendor string
+
+const (
+	AcceleratorVendorNvidia      AcceleratorVendor = "nvidia"
+	AcceleratorVendorAMD         AcceleratorVendor = "amd"
+	AcceleratorVendorIntelHabana AcceleratorVendor = "intel-habana"
+	AcceleratorVendorAWSNeuron   AcceleratorVendor = "aws-neuron"
+)
+
+// AcceleratorConfig configures a single extended-resource accelerator on the node,
+// so that nodeup can install the right driver, container runtime hooks, and device
+// plugin for whichever vendor of hardware the instance group uses.
+type AcceleratorConfig struct {
+	// Vendor is the accelerator vendor, e.g. AcceleratorVendorNvidia.
+	Vendor AcceleratorVendor `json:",omitempty"`
+	// DriverVersion is the vendor driver version to install.
+	DriverVersion string `json:",omitempty"`
+	// DevicePluginImage is the device plugin daemonset image to preload.
+	DevicePluginImage string `json:",omitempty"`
+	// RuntimeClass is the container runtime class for pods that request this
+	// accelerator. nodeup does not register it.
+	RuntimeClass string `json:",omitempty"`
+	// NodeLabels are additional labels for the node for this accelerator.
+	// nodeup does not apply them.
+	NodeLabels map[string]string `json:",omitempty"`
+	// NodeTaints are additional taints for the node for this accelerator.
+	// nodeup does not apply them.
+	NodeTaints []string `json:",omitempty"`
+	// Options holds vendor-specific configuration that doesn't warrant its own field.
+	Options map[string]string `json:",omitempty"`
+}
+
+// nvidiaAcceleratorConfig translates the legacy Config.NvidiaGPU field into an
+// AcceleratorConfig, so that older clusters keep working unchanged.
+func nvidiaAcceleratorConfig(nvidiaGPU *kops.NvidiaGPUConfig) AcceleratorConfig {
+	accelerator := AcceleratorConfig{
+		Vendor: AcceleratorVendorNvidia,
+	}
+	if nvidiaGPU != nil {
+		accelerator.DriverVersion = nvidiaGPU.DriverVersion
+	}
+	return accelerator
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import "k8s.io/kops/pkg/apis/kops"
+
+// BlockDeviceMapping describes an additional volume that nodeup should format
+// and mount at boot, distinct from the root and any ephemeral volumes that
+// kops already manages.
+type BlockDeviceMapping struct {
+	// DeviceName is the device name as exposed to the instance, e.g. "/dev/xvdc".
+	DeviceName string `json:",omitempty"`
+	// VolumeSize is the size of the volume, in GB.
+	VolumeSize int64 `json:",omitempty"`
+	// VolumeType is the cloud-specific volume type, e.g. "gp3".
+	VolumeType string `json:",omitempty"`
+	// IOPS is the provisioned IOPS for volume types that support it.
+	IOPS *int64 `json:",omitempty"`
+	// Throughput is the provisioned throughput (MB/s) for volume types that support it.
+	Throughput *int64 `json:",omitempty"`
+	// Encrypted indicates whether the volume should be encrypted.
+	Encrypted *bool `json:",omitempty"`
+	// KMSKeyID is the ID of the KMS key used to encrypt the volume, if any.
+	KMSKeyID string `json:",omitempty"`
+	// Filesystem is the filesystem to format the volume with, e.g. "xfs" or "ext4".
+	Filesystem string `json:",omitempty"`
+	// MountPoint is the path at which the volume should be mounted.
+	MountPoint string `json:",omitempty"`
+}
+
+// toNodeupBlockDeviceMappings converts the kops API block device mappings for
+// an instance group into the nodeup representation nodeup uses to reconcile them.
+func toNodeupBlockDeviceMappings(specs []kops.BlockDeviceMappingSpec) []BlockDeviceMapping {
+	var mappings []BlockDeviceMapping
+	for _, spec := range specs {
+		mappings = append(mappings, BlockDeviceMapping{
+			DeviceName: spec.DeviceName,
+			VolumeSize: spec.VolumeSize,
+			VolumeType: spec.VolumeType,
+			IOPS:       spec.IOPS,
+			Throughput: spec.Throughput,
+			Encrypted:  spec.Encrypted,
+			KMSKeyID:   spec.KMSKeyID,
+			Filesystem: spec.Filesystem,
+			MountPoint: spec.MountPoint,
+		})
+	}
+	return mappings
+}
@@ -64,6 +64,8 @@ type Config struct {
 	UpdatePolicy string
 	// VolumeMounts are a collection of volume mounts.
 	VolumeMounts []kops.VolumeMountSpec `json:",omitempty"`
+	// BlockDeviceMappings are additional volumes that nodeup should format and mount at boot.
+	BlockDeviceMappings []BlockDeviceMapping `json:",omitempty"`
 
 	// FileAssets are a collection of file assets for this instance group.
 	FileAssets []kops.FileAssetSpec `json:",omitempty"`
@@ -76,8 +78,19 @@ type Config struct {
 
 	// APIServerConfig is additional configuration for nodes running an APIServer.
 	APIServerConfig *APIServerConfig `json:",omitempty"`
-	// NvidiaGPU contains the configuration for nvidia
+	// NvidiaGPU contains the configuration for nvidia.
+	//
+	// Deprecated: use Accelerators instead. NewConfig still populates this field
+	// for backwards compatibility, translating it into an Accelerators entry.
 	NvidiaGPU *kops.NvidiaGPUConfig `json:",omitempty"`
+	// Accelerators configures the extended-resource accelerators (GPUs and similar
+	// devices) nodeup should install drivers, runtime hooks, and device plugins for.
+	Accelerators []AcceleratorConfig `json:",omitempty"`
+
+	// WindowsConfig holds the configuration for nodeup on Windows instance groups.
+	// It is populated instead of KubeletConfig and ContainerdConfig when the
+	// instance group's OS is OSWindows.
+	WindowsConfig *WindowsConfig `json:",omitempty"`
 
 	// AWS-specific
 	// DisableSecurityGroupIngress disables the Cloud Controller Manager's creation
@@ -91,8 +104,16 @@ type Config struct {
 	NodeIPFamilies []string `json:"nodeIPFamilies,omitempty"`
 	// UseInstanceIDForNodeName uses the instance ID instead of the hostname for the node name.
 	UseInstanceIDForNodeName bool `json:"useInstanceIDForNodeName,omitempty"`
-	// WarmPoolImages are the container images to pre-pull during instance pre-initialization
-	WarmPoolImages []string `json:"warmPoolImages,omitempty"`
+	// WarmPoolImages are the container images to pre-pull during instance pre-initialization.
+	// This is keyed by OS, rather than being the plain []*Image that the main Images field
+	// uses, because OSWindows instance groups joined the warm pool in the Windows support
+	// added above: without the OS key, a Linux warm pool node would pre-pull Windows images
+	// (and vice versa) that it can never run. Each image carries a Hash, exactly as Images
+	// does, so nodeup can verify its integrity before trusting it.
+	WarmPoolImages map[OS][]*Image `json:"warmPoolImages,omitempty"`
+	// WarmPoolRegistryAuth holds, per registry host, a reference to the kops secret
+	// store entry nodeup should use to authenticate when pre-pulling warm pool images.
+	WarmPoolRegistryAuth map[string]RegistryCredential `json:"warmPoolRegistryAuth,omitempty"`
 
 	// GCE-specific
 	Multizone          *bool   `json:"multizone,omitempty"`
@@ -115,7 +136,16 @@ type BootConfig struct {
 	InstanceGroupName string `json:",omitempty"`
 	// InstanceGroupRole is the instance group role.
 	InstanceGroupRole kops.InstanceGroupRole
-	// NodeupConfigHash holds a secure hash of the nodeup.Config.
+	// OS is the operating system of the instance group, used to select the
+	// correct nodeup bootstrap flow.
+	OS OS `json:",omitempty"`
+	// ConfigSchemaVersion is the ConfigSchemaVersion this nodeup was built with.
+	// kops-controller rejects a nodeup reporting a version it doesn't understand,
+	// rather than silently mis-applying config.
+	ConfigSchemaVersion int `json:",omitempty"`
+	// NodeupConfigHash holds the hash computed by ComputeNodeupConfigHash, which
+	// incorporates ConfigSchemaVersion as well as the Config contents, so that a
+	// schema bump alone forces a rolling replacement of every node.
 	NodeupConfigHash string
 }
 
@@ -136,6 +166,14 @@ type Image struct {
 	Hash string `json:"hash,omitempty"`
 }
 
+// RegistryCredential is a reference to a kops secret store entry holding
+// registry credentials, rather than the credential itself, so that it is
+// safe to include in the nodeup.Config.
+type RegistryCredential struct {
+	// SecretName is the name of the secret in the kops secret store.
+	SecretName string `json:"secretName,omitempty"`
+}
+
 // StaticManifest is a generic static manifest
 type StaticManifest struct {
 	// Key identifies the static manifest
@@ -163,20 +201,25 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 	igHooks := filterHooks(instanceGroup.Spec.Hooks, instanceGroup.Spec.Role)
 
 	config := Config{
-		ClusterName:      cluster.ObjectMeta.Name,
-		CAs:              map[string]string{},
-		KeypairIDs:       map[string]string{},
-		SysctlParameters: instanceGroup.Spec.SysctlParameters,
-		VolumeMounts:     instanceGroup.Spec.VolumeMounts,
-		FileAssets:       append(filterFileAssets(instanceGroup.Spec.FileAssets, role), filterFileAssets(cluster.Spec.FileAssets, role)...),
-		Hooks:            [][]kops.HookSpec{igHooks, clusterHooks},
-		ContainerRuntime: cluster.Spec.ContainerRuntime,
+		ClusterName:         cluster.ObjectMeta.Name,
+		CAs:                 map[string]string{},
+		KeypairIDs:          map[string]string{},
+		SysctlParameters:    instanceGroup.Spec.SysctlParameters,
+		VolumeMounts:        instanceGroup.Spec.VolumeMounts,
+		BlockDeviceMappings: toNodeupBlockDeviceMappings(instanceGroup.Spec.BlockDeviceMappings),
+		FileAssets:          append(filterFileAssets(instanceGroup.Spec.FileAssets, role), filterFileAssets(cluster.Spec.FileAssets, role)...),
+		Hooks:               [][]kops.HookSpec{igHooks, clusterHooks},
+		ContainerRuntime:    cluster.Spec.ContainerRuntime,
 	}
 
+	nodeOS := detectOS(instanceGroup)
+
 	bootConfig := BootConfig{
-		CloudProvider:     cluster.Spec.GetCloudProvider(),
-		InstanceGroupName: instanceGroup.ObjectMeta.Name,
-		InstanceGroupRole: role,
+		CloudProvider:       cluster.Spec.GetCloudProvider(),
+		InstanceGroupName:   instanceGroup.ObjectMeta.Name,
+		InstanceGroupRole:   role,
+		OS:                  nodeOS,
+		ConfigSchemaVersion: ConfigSchemaVersion,
 	}
 
 	if cluster.Spec.CloudProvider.AWS != nil {
@@ -186,6 +229,25 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 			config.EnableLifecycleHook = true
 		}
 
+		if warmPool.IsEnabled() && len(warmPool.Images) > 0 {
+			config.WarmPoolImages = map[OS][]*Image{}
+			for os, images := range warmPool.Images {
+				for _, image := range images {
+					config.WarmPoolImages[OS(os)] = append(config.WarmPoolImages[OS(os)], &Image{
+						Name:    image.Name,
+						Sources: image.Sources,
+						Hash:    image.Hash,
+					})
+				}
+			}
+		}
+		if warmPool.IsEnabled() && len(warmPool.RegistryAuth) > 0 {
+			config.WarmPoolRegistryAuth = map[string]RegistryCredential{}
+			for registry, credential := range warmPool.RegistryAuth {
+				config.WarmPoolRegistryAuth[registry] = RegistryCredential{SecretName: credential.SecretName}
+			}
+		}
+
 		if instanceGroup.HasAPIServer() || cluster.IsKubernetesLT("1.24") {
 			config.DisableSecurityGroupIngress = aws.DisableSecurityGroupIngress
 			config.ElbSecurityGroup = aws.ElbSecurityGroup
@@ -216,8 +278,39 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		config.UseInstanceIDForNodeName = true
 	}
 
-	if instanceGroup.Spec.Kubelet != nil {
-		config.KubeletConfig = *instanceGroup.Spec.Kubelet
+	if instanceGroup.Spec.NvidiaGPU != nil {
+		config.NvidiaGPU = instanceGroup.Spec.NvidiaGPU
+	}
+	if config.NvidiaGPU != nil {
+		config.Accelerators = append(config.Accelerators, nvidiaAcceleratorConfig(config.NvidiaGPU))
+	}
+	for _, accelerator := range instanceGroup.Spec.Accelerators {
+		config.Accelerators = append(config.Accelerators, AcceleratorConfig{
+			Vendor:            AcceleratorVendor(accelerator.Vendor),
+			DriverVersion:     accelerator.DriverVersion,
+			DevicePluginImage: accelerator.DevicePluginImage,
+			RuntimeClass:      accelerator.RuntimeClass,
+			NodeLabels:        accelerator.NodeLabels,
+			NodeTaints:        accelerator.NodeTaints,
+			Options:           accelerator.Options,
+		})
+	}
+
+	switch nodeOS {
+	case OSWindows:
+		windowsConfig := &WindowsConfig{
+			ContainerdSandboxIsolation: ContainerdSandboxIsolationProcess,
+			WindowsBaseImageSKU:        instanceGroup.Spec.Image,
+			PauseImage:                 DefaultWindowsPauseImage,
+		}
+		if instanceGroup.Spec.WindowsKubelet != nil {
+			windowsConfig.WindowsKubelet = *instanceGroup.Spec.WindowsKubelet
+		}
+		config.WindowsConfig = windowsConfig
+	default:
+		if instanceGroup.Spec.Kubelet != nil {
+			config.KubeletConfig = *instanceGroup.Spec.Kubelet
+		}
 	}
 
 	if instanceGroup.HasAPIServer() {
@@ -226,6 +319,10 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		}
 	}
 
+	if hash, err := ComputeNodeupConfigHash(&config); err == nil {
+		bootConfig.NodeupConfigHash = hash
+	}
+
 	return &config, &bootConfig
 }
 
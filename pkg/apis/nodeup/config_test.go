@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func testCluster() *kops.Cluster {
+	cluster := &kops.Cluster{}
+	cluster.ObjectMeta.Name = "test.k8s.local"
+	cluster.Spec.ContainerRuntime = "containerd"
+	return cluster
+}
+
+func TestNewConfig_LinuxInstanceGroup(t *testing.T) {
+	cluster := testCluster()
+	ig := &kops.InstanceGroup{}
+	ig.Spec.Role = kops.InstanceGroupRoleNode
+	ig.Spec.Kubelet = &kops.KubeletConfigSpec{}
+
+	config, bootConfig := NewConfig(cluster, ig)
+
+	if bootConfig.OS != OSLinux {
+		t.Errorf("OS = %q, want %q", bootConfig.OS, OSLinux)
+	}
+	if config.WindowsConfig != nil {
+		t.Errorf("WindowsConfig = %+v, want nil for a Linux instance group", config.WindowsConfig)
+	}
+}
+
+func TestNewConfig_WindowsInstanceGroup(t *testing.T) {
+	cluster := testCluster()
+	ig := &kops.InstanceGroup{}
+	ig.Spec.Role = kops.InstanceGroupRoleNode
+	ig.Spec.OS = "windows"
+	ig.Spec.Image = "Datacenter-Core-2019-with-Containers-smalldisk"
+	ig.Spec.Kubelet = &kops.KubeletConfigSpec{}
+	ig.Spec.WindowsKubelet = &kops.KubeletConfigSpec{}
+
+	config, bootConfig := NewConfig(cluster, ig)
+
+	if bootConfig.OS != OSWindows {
+		t.Errorf("OS = %q, want %q", bootConfig.OS, OSWindows)
+	}
+	if config.WindowsConfig == nil {
+		t.Fatal("WindowsConfig = nil, want non-nil for a Windows instance group")
+	}
+	if config.WindowsConfig.WindowsBaseImageSKU != ig.Spec.Image {
+		t.Errorf("WindowsBaseImageSKU = %q, want %q", config.WindowsConfig.WindowsBaseImageSKU, ig.Spec.Image)
+	}
+	if config.WindowsConfig.PauseImage != DefaultWindowsPauseImage {
+		t.Errorf("PauseImage = %q, want %q", config.WindowsConfig.PauseImage, DefaultWindowsPauseImage)
+	}
+}
+
+func TestNewConfig_NvidiaGPUTranslatedToAccelerators(t *testing.T) {
+	cluster := testCluster()
+	ig := &kops.InstanceGroup{}
+	ig.Spec.Role = kops.InstanceGroupRoleNode
+	ig.Spec.NvidiaGPU = &kops.NvidiaGPUConfig{DriverVersion: "535.104.05"}
+
+	config, _ := NewConfig(cluster, ig)
+
+	if config.NvidiaGPU == nil || config.NvidiaGPU.DriverVersion != "535.104.05" {
+		t.Fatalf("NvidiaGPU = %+v, want DriverVersion 535.104.05", config.NvidiaGPU)
+	}
+
+	var found bool
+	for _, accelerator := range config.Accelerators {
+		if accelerator.Vendor == AcceleratorVendorNvidia && accelerator.DriverVersion == "535.104.05" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Accelerators = %+v, want an nvidia entry translated from NvidiaGPU", config.Accelerators)
+	}
+}
+
+func TestNewConfig_SetsNodeupConfigHash(t *testing.T) {
+	cluster := testCluster()
+	ig := &kops.InstanceGroup{}
+	ig.Spec.Role = kops.InstanceGroupRoleNode
+
+	_, bootConfig := NewConfig(cluster, ig)
+
+	if bootConfig.ConfigSchemaVersion != ConfigSchemaVersion {
+		t.Errorf("ConfigSchemaVersion = %d, want %d", bootConfig.ConfigSchemaVersion, ConfigSchemaVersion)
+	}
+	if bootConfig.NodeupConfigHash == "" {
+		t.Error("NodeupConfigHash = \"\", want a non-empty hash")
+	}
+}
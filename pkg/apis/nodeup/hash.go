@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ComputeNodeupConfigHash computes the secure hash to store in
+// BootConfig.NodeupConfigHash. It hashes over the marshaled Config together
+// with ConfigSchemaVersion, so that a schema-version bump alone changes the
+// hash for every node, without requiring any other field to change, and
+// forces a rolling replacement through the existing rolling-update path.
+func ComputeNodeupConfigHash(config *Config) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("marshaling nodeup config: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(data)
+	fmt.Fprintf(h, "\x00schemaVersion=%d", ConfigSchemaVersion)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// OS identifies the node's operating system, so that nodeup can select the
+// correct bootstrap flow.
+type OS string
+
+const (
+	// OSLinux is the default OS for kops nodes.
+	OSLinux OS = "linux"
+	// OSWindows selects the Windows bootstrap flow.
+	OSWindows OS = "windows"
+)
+
+// detectOS determines the OS of an instance group, defaulting to OSLinux.
+func detectOS(instanceGroup *kops.InstanceGroup) OS {
+	if strings.EqualFold(instanceGroup.Spec.OS, string(OSWindows)) {
+		return OSWindows
+	}
+	if strings.Contains(strings.ToLower(instanceGroup.Spec.Image), "windows") {
+		return OSWindows
+	}
+	return OSLinux
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+// ConfigSchemaVersion identifies the shape of Config that participates in
+// bootstrap decisions. Bump it whenever a field's default changes, a field
+// that participates in bootstrap decisions is added, or such a field is
+// removed. Bumping it alone changes BootConfig.NodeupConfigHash for every
+// node, forcing a rolling replacement through the existing rolling-update path.
+//
+// v2: WindowsConfig.WindowsBaseImageSKU and PauseImage are now populated by
+// NewConfig instead of always being empty, changing the effective default
+// for Windows instance groups.
+const ConfigSchemaVersion = 2
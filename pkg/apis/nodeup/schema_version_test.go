@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// configSchemaVersionGoldenFields is a snapshot of Config's exported field
+// names as of ConfigSchemaVersion. If this test fails, a field was added or
+// removed: bump ConfigSchemaVersion and update this snapshot in the same commit.
+var configSchemaVersionGoldenFields = []string{
+	"APIServerConfig",
+	"Accelerators",
+	"ApiserverAdditionalIPs",
+	"Assets",
+	"BlockDeviceMappings",
+	"CAs",
+	"Channels",
+	"ClusterName",
+	"ContainerRuntime",
+	"ContainerdConfig",
+	"DefaultMachineType",
+	"DisableSecurityGroupIngress",
+	"ElbSecurityGroup",
+	"EnableLifecycleHook",
+	"EtcdManifests",
+	"FileAssets",
+	"Hooks",
+	"Images",
+	"KeypairIDs",
+	"KubeletConfig",
+	"Multizone",
+	"NodeIPFamilies",
+	"NodeInstancePrefix",
+	"NodeTags",
+	"NvidiaGPU",
+	"Packages",
+	"StaticManifests",
+	"SysctlParameters",
+	"UpdatePolicy",
+	"UseInstanceIDForNodeName",
+	"VolumeMounts",
+	"WarmPoolImages",
+	"WarmPoolRegistryAuth",
+	"WindowsConfig",
+}
+
+func TestConfigSchemaVersionMatchesFieldSet(t *testing.T) {
+	typ := reflect.TypeOf(Config{})
+
+	var got []string
+	for i := 0; i < typ.NumField(); i++ {
+		got = append(got, typ.Field(i).Name)
+	}
+	sort.Strings(got)
+
+	want := append([]string(nil), configSchemaVersionGoldenFields...)
+	sort.Strings(want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Config's exported field set no longer matches the ConfigSchemaVersion=%d golden "+
+			"snapshot.\nThis test exists to remind you: if the change affects bootstrap decisions, bump "+
+			"ConfigSchemaVersion and update configSchemaVersionGoldenFields to match.\ngot:  %v\nwant: %v",
+			ConfigSchemaVersion, got, want)
+	}
+}
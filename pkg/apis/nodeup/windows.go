@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import "k8s.io/kops/pkg/apis/kops"
+
+const (
+	// KubernetesWindowsDockerVersion is the default docker version installed on Windows nodes.
+	KubernetesWindowsDockerVersion = "20.10.21"
+	// ContainerdSandboxIsolationProcess runs Windows pods without Hyper-V isolation.
+	ContainerdSandboxIsolationProcess = "process"
+	// ContainerdSandboxIsolationHyperV runs Windows pods inside Hyper-V isolation.
+	ContainerdSandboxIsolationHyperV = "hyperv"
+	// DefaultWindowsPauseImage is the pause image NewConfig sets on Windows nodes.
+	// InstanceGroupSpec has no field to choose a different one.
+	DefaultWindowsPauseImage = "mcr.microsoft.com/oss/kubernetes/pause:3.9"
+)
+
+// WindowsConfig is the configuration for the nodeup binary on Windows nodes.
+type WindowsConfig struct {
+	// ContainerdSandboxIsolation selects the containerd sandbox isolation mode,
+	// either ContainerdSandboxIsolationProcess or ContainerdSandboxIsolationHyperV.
+	ContainerdSandboxIsolation string `json:",omitempty"`
+	// WindowsBaseImageSKU is the Windows Server base image SKU the node was launched
+	// from, e.g. "Datacenter-Core-2019-with-Containers-smalldisk".
+	WindowsBaseImageSKU string `json:",omitempty"`
+	// PauseImage is the pause container image to use for Windows pod sandboxes.
+	PauseImage string `json:",omitempty"`
+	// WindowsKubelet defines the kubelet configuration for Windows nodes, from
+	// InstanceGroupSpec.WindowsKubelet. It is distinct from KubeletConfig,
+	// which is populated from InstanceGroupSpec.Kubelet and only applies to
+	// Linux nodes.
+	WindowsKubelet kops.KubeletConfigSpec `json:",omitempty"`
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// additionalBlockDeviceMappings converts the instance group's extra
+// BlockDeviceMappings into EC2 launch template block device mappings, so the
+// volumes nodeup is asked to format and mount are actually attached to the instance.
+func additionalBlockDeviceMappings(mappings []kops.BlockDeviceMappingSpec) []*ec2.LaunchTemplateBlockDeviceMappingRequest {
+	var result []*ec2.LaunchTemplateBlockDeviceMappingRequest
+	for _, mapping := range mappings {
+		ebs := &ec2.LaunchTemplateEbsBlockDeviceRequest{
+			VolumeType: aws.String(mapping.VolumeType),
+			VolumeSize: aws.Int64(mapping.VolumeSize),
+			Encrypted:  mapping.Encrypted,
+		}
+		if mapping.IOPS != nil {
+			ebs.Iops = mapping.IOPS
+		}
+		if mapping.Throughput != nil {
+			ebs.Throughput = mapping.Throughput
+		}
+		if mapping.KMSKeyID != "" {
+			ebs.KmsKeyId = aws.String(mapping.KMSKeyID)
+		}
+
+		result = append(result, &ec2.LaunchTemplateBlockDeviceMappingRequest{
+			DeviceName: aws.String(mapping.DeviceName),
+			Ebs:        ebs,
+		})
+	}
+	return result
+}
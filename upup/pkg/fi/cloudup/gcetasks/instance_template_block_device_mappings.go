@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcetasks
+
+import (
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// additionalDisks converts the instance group's extra BlockDeviceMappings into
+// GCE instance template disks, so the volumes nodeup is asked to format and
+// mount are actually attached to the instance.
+//
+// GCE has no equivalent of IOPS/Throughput, so those are not carried over.
+// Encrypted/KMSKeyID are mapped onto DiskEncryptionKey: GCE already encrypts
+// disks at rest by default, so a mapping is only emitted when the instance
+// group asked for customer-managed encryption via KMSKeyID.
+func additionalDisks(mappings []kops.BlockDeviceMappingSpec) []*compute.AttachedDisk {
+	var result []*compute.AttachedDisk
+	for _, mapping := range mappings {
+		disk := &compute.AttachedDisk{
+			DeviceName: mapping.DeviceName,
+			DiskSizeGb: mapping.VolumeSize,
+			Type:       mapping.VolumeType,
+		}
+		if mapping.Encrypted != nil && *mapping.Encrypted && mapping.KMSKeyID != "" {
+			disk.DiskEncryptionKey = &compute.CustomerEncryptionKey{
+				KmsKeyName: mapping.KMSKeyID,
+			}
+		}
+		result = append(result, disk)
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import "context"
+
+// ConfigServer is nodeup's client for kops-controller's configuration server,
+// used to fetch secrets (such as registry credentials) by name rather than
+// embedding them directly in nodeup.Config.
+type ConfigServer interface {
+	GetSecret(ctx context.Context, name string) (*Secret, error)
+}
+
+// Secret is a credential fetched from the kops secret store.
+type Secret struct {
+	Username string
+	Password string
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import "fmt"
+
+// Lifecycle indicates whether a task should be created, checked for drift only, or ignored.
+type Lifecycle string
+
+const (
+	LifecycleSync                   Lifecycle = "Sync"
+	LifecycleExistsAndWarnIfChanges Lifecycle = "ExistsAndWarnIfChanges"
+)
+
+// HasLifecycle is implemented by tasks that support a Lifecycle.
+type HasLifecycle interface {
+	GetLifecycle() *Lifecycle
+	SetLifecycle(lifecycle Lifecycle)
+}
+
+// HasName is implemented by tasks that have a user-visible Name.
+type HasName interface {
+	GetName() *string
+}
+
+// TaskAsString renders a task as a readable string for logging, using its name if it has one.
+func TaskAsString(task interface{}) string {
+	if named, ok := task.(HasName); ok && named.GetName() != nil {
+		return fmt.Sprintf("%T{%s}", task, *named.GetName())
+	}
+	return fmt.Sprintf("%T", task)
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+// NodeupModelBuilder adds Tasks to a NodeupModelBuilderContext based on the
+// node's configuration. Each nodeup/pkg/model package implements one of these
+// per concern (kubelet, containerd, an accelerator, ...).
+type NodeupModelBuilder interface {
+	Build(c *NodeupModelBuilderContext) error
+}
+
+// NodeupModelBuilderContext collects the Tasks produced by a NodeupModelBuilder.
+type NodeupModelBuilderContext struct {
+	tasks []Task
+}
+
+// AddTask registers a task to be run while applying the node's configuration.
+func (c *NodeupModelBuilderContext) AddTask(task Task) {
+	c.tasks = append(c.tasks, task)
+}
+
+// Tasks returns the tasks collected so far.
+func (c *NodeupModelBuilderContext) Tasks() []Task {
+	return c.tasks
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+// Context is passed to a Task's Run method, carrying whatever state nodeup
+// tasks need while applying the node's configuration.
+type Context struct{}
+
+// Task is a unit of work nodeup performs while applying its configuration.
+type Task interface {
+	// Run performs the task's work.
+	Run(c *Context) error
+	// String returns a readable description of the task, for logging.
+	String() string
+}